@@ -0,0 +1,195 @@
+package fin
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Code-Hex/fin/internal/header"
+	"github.com/Code-Hex/fin/internal/mime"
+)
+
+// Validator is implemented by types that can validate a bound struct.
+// Register one on Engine to have Bind and its BindXxx variants run
+// validation automatically after decoding.
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+func (c *ctx) Bind(i interface{}) error {
+	if err := bindTagged(i, "param", paramsToValues(c.params)); err != nil {
+		return err
+	}
+	if err := bindTagged(i, "query", c.QueryParams()); err != nil {
+		return err
+	}
+	if err := bindTagged(i, "header", canonicalHeaderValues(c.request.Header)); err != nil {
+		return err
+	}
+	// ContentLength is -1 for chunked/unknown-length bodies (e.g.
+	// Transfer-Encoding: chunked), so only a confirmed-empty body (0)
+	// skips decoding; anything else, including -1, must still attempt it.
+	if c.request.ContentLength != 0 {
+		ctype := c.request.Header.Get(header.ContentType)
+		switch {
+		case strings.HasPrefix(ctype, mime.ApplicationJSON):
+			if err := json.NewDecoder(c.request.Body).Decode(i); err != nil {
+				return err
+			}
+		case strings.HasPrefix(ctype, mime.ApplicationXML), strings.HasPrefix(ctype, mime.TextXML):
+			if err := xml.NewDecoder(c.request.Body).Decode(i); err != nil {
+				return err
+			}
+		case strings.HasPrefix(ctype, mime.ApplicationForm), strings.HasPrefix(ctype, mime.MultipartForm):
+			form, err := c.FormParams()
+			if err != nil {
+				return err
+			}
+			if err := bindTagged(i, "form", form); err != nil {
+				return err
+			}
+		}
+	}
+	return c.validate(i)
+}
+
+func (c *ctx) BindJSON(i interface{}) error {
+	if err := json.NewDecoder(c.request.Body).Decode(i); err != nil {
+		return err
+	}
+	return c.validate(i)
+}
+
+func (c *ctx) BindXML(i interface{}) error {
+	if err := xml.NewDecoder(c.request.Body).Decode(i); err != nil {
+		return err
+	}
+	return c.validate(i)
+}
+
+func (c *ctx) BindQuery(i interface{}) error {
+	if err := bindTagged(i, "query", c.QueryParams()); err != nil {
+		return err
+	}
+	return c.validate(i)
+}
+
+func (c *ctx) BindHeader(i interface{}) error {
+	if err := bindTagged(i, "header", canonicalHeaderValues(c.request.Header)); err != nil {
+		return err
+	}
+	return c.validate(i)
+}
+
+func (c *ctx) validate(i interface{}) error {
+	if c.validator == nil {
+		return nil
+	}
+	return c.validator.Validate(i)
+}
+
+// canonicalHeaderValues converts a http.Header into url.Values keyed by
+// the canonical MIME header form, so lookups by tag name are
+// case-insensitive the same way http.Header.Get is.
+func canonicalHeaderValues(h http.Header) url.Values {
+	values := make(url.Values, len(h))
+	for k, v := range h {
+		values[textproto.CanonicalMIMEHeaderKey(k)] = v
+	}
+	return values
+}
+
+// paramsToValues is a function rather than a Params method because
+// Params is an alias of httprouter.Params, and Go forbids attaching
+// methods to a type defined in another package.
+func paramsToValues(p Params) url.Values {
+	values := make(url.Values, len(p))
+	for _, param := range p {
+		values[param.Key] = []string{param.Value}
+	}
+	return values
+}
+
+// bindTagged populates the exported fields of i, a pointer to a struct,
+// from values using the given struct tag name. Header lookups must pass
+// values already keyed with textproto.CanonicalMIMEHeaderKey.
+func bindTagged(i interface{}, tag string, values url.Values) error {
+	if len(values) == 0 {
+		return nil
+	}
+	v := reflect.ValueOf(i)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+	t := v.Type()
+	if tag == "header" {
+		for idx := 0; idx < t.NumField(); idx++ {
+			field := t.Field(idx)
+			name := field.Tag.Get(tag)
+			if name == "" {
+				continue
+			}
+			name = textproto.CanonicalMIMEHeaderKey(name)
+			if raw, ok := values[name]; ok && len(raw) > 0 {
+				if err := setField(v.Field(idx), raw[0]); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		name := field.Tag.Get(tag)
+		if name == "" {
+			continue
+		}
+		if raw, ok := values[name]; ok && len(raw) > 0 {
+			if err := setField(v.Field(idx), raw[0]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, raw string) error {
+	if !field.CanSet() {
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	}
+	return nil
+}
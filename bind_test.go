@@ -0,0 +1,94 @@
+package fin
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestBindTagged(t *testing.T) {
+	type target struct {
+		Name   string `query:"name"`
+		Age    int    `query:"age"`
+		Active bool   `query:"active"`
+	}
+
+	var got target
+	values := url.Values{
+		"name":   {"alice"},
+		"age":    {"30"},
+		"active": {"true"},
+	}
+	if err := bindTagged(&got, "query", values); err != nil {
+		t.Fatalf("bindTagged() error = %v", err)
+	}
+	want := target{Name: "alice", Age: 30, Active: true}
+	if got != want {
+		t.Fatalf("bindTagged() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBindTaggedIgnoresUntaggedAndMissingFields(t *testing.T) {
+	type target struct {
+		Name     string `query:"name"`
+		Internal string
+	}
+	got := target{Internal: "untouched"}
+	if err := bindTagged(&got, "query", url.Values{"other": {"x"}}); err != nil {
+		t.Fatalf("bindTagged() error = %v", err)
+	}
+	if got.Name != "" || got.Internal != "untouched" {
+		t.Fatalf("bindTagged() = %+v, want zero-value Name and untouched Internal", got)
+	}
+}
+
+func TestSetField(t *testing.T) {
+	type target struct {
+		S string
+		I int
+		B bool
+		F float64
+	}
+	var got target
+	v := reflect.ValueOf(&got).Elem()
+
+	if err := setField(v.FieldByName("S"), "hello"); err != nil {
+		t.Fatalf("setField(S) error = %v", err)
+	}
+	if err := setField(v.FieldByName("I"), "42"); err != nil {
+		t.Fatalf("setField(I) error = %v", err)
+	}
+	if err := setField(v.FieldByName("B"), "true"); err != nil {
+		t.Fatalf("setField(B) error = %v", err)
+	}
+	if err := setField(v.FieldByName("F"), "3.14"); err != nil {
+		t.Fatalf("setField(F) error = %v", err)
+	}
+
+	want := target{S: "hello", I: 42, B: true, F: 3.14}
+	if got != want {
+		t.Fatalf("setField() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSetFieldInvalidValue(t *testing.T) {
+	var got struct{ I int }
+	v := reflect.ValueOf(&got).Elem()
+	if err := setField(v.FieldByName("I"), "not-a-number"); err == nil {
+		t.Fatal("setField() error = nil, want error for invalid int")
+	}
+}
+
+func TestCanonicalHeaderValues(t *testing.T) {
+	h := map[string][]string{
+		"x-request-id": {"abc"},
+		"X-AUTH-TOKEN": {"secret"},
+	}
+	got := canonicalHeaderValues(h)
+	if got.Get("X-Request-Id") != "abc" {
+		t.Errorf("canonicalHeaderValues()[X-Request-Id] = %q, want %q", got.Get("X-Request-Id"), "abc")
+	}
+	if got.Get("X-Auth-Token") != "secret" {
+		t.Errorf("canonicalHeaderValues()[X-Auth-Token] = %q, want %q", got.Get("X-Auth-Token"), "secret")
+	}
+}
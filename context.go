@@ -1,12 +1,16 @@
 package fin
 
 import (
+	stdctx "context"
 	"encoding/json"
 	"encoding/xml"
+	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/lestrrat/go-xslate"
 	"go.uber.org/zap"
@@ -20,6 +24,15 @@ type ctx struct {
 	logger     *zap.Logger
 	xslate     *xslate.Xslate
 	errhandler func(error, Context)
+	validator  Validator
+
+	protobufEncoder ProtobufEncoder
+	msgPackEncoder  MsgPackEncoder
+
+	trustedProxies    []*net.IPNet
+	signingKeys       [][]byte
+	defaultRenderType string
+	stdctx            stdctx.Context
 
 	request  *http.Request
 	response *Response
@@ -50,6 +63,15 @@ type Context interface {
 	SetCookie(*http.Cookie)
 	Cookies() []*http.Cookie
 
+	SignedCookie(name, value string, opts CookieOptions) error
+	GetSignedCookie(name string) (string, error)
+
+	Bind(i interface{}) error
+	BindJSON(i interface{}) error
+	BindXML(i interface{}) error
+	BindQuery(i interface{}) error
+	BindHeader(i interface{}) error
+
 	Handler() HandlerFunc
 	SetHandler(HandlerFunc)
 
@@ -66,8 +88,35 @@ type Context interface {
 
 	SetContentType(code int, contentType string)
 	JSON(code int, i interface{}) error
+	JSONBlob(code int, b []byte) error
+	JSONP(code int, callback string, i interface{}) error
 	XML(code int, i interface{}) error
+	XMLBlob(code int, b []byte) error
 	String(code int, content string) error
+	Protobuf(code int, m interface{}) error
+	MsgPack(code int, i interface{}) error
+
+	Blob(code int, contentType string, b []byte) error
+	Stream(code int, contentType string, r io.Reader) error
+	File(file string) error
+	Attachment(file, name string) error
+	Inline(file, name string) error
+
+	IsTLS() bool
+	IsWebSocket() bool
+	Scheme() string
+	RealIP() string
+
+	Accepts(offers ...string) string
+	AcceptsCharsets(offers ...string) string
+	AcceptsEncodings(offers ...string) string
+	AcceptsLanguages(offers ...string) string
+	Respond(code int, data interface{}) error
+
+	stdctx.Context
+	StdContext() stdctx.Context
+	SetStdContext(stdctx.Context)
+	WithTimeout(d time.Duration) (Context, CancelFunc)
 }
 
 const (
@@ -230,14 +279,21 @@ func (c *ctx) String(code int, s string) (err error) {
 // NewContext returns a Context instance.
 func (e *Engine) NewContext(w http.ResponseWriter, r *http.Request) Context {
 	return &ctx{
-		logger:     e.Logger,
-		xslate:     e.Xslate,
-		errhandler: e.HTTPErrorHandler,
+		logger:            e.Logger,
+		xslate:            e.Xslate,
+		errhandler:        e.HTTPErrorHandler,
+		validator:         e.Validator,
+		protobufEncoder:   e.ProtobufEncoder,
+		msgPackEncoder:    e.MsgPackEncoder,
+		trustedProxies:    e.TrustedProxies,
+		signingKeys:       e.SigningKeys,
+		defaultRenderType: e.DefaultRenderType,
 
 		request:  r,
 		response: NewResponse(w),
 		store:    new(sync.Map),
 		handler:  NotFoundHandler,
+		stdctx:   r.Context(),
 	}
 }
 
@@ -249,6 +305,7 @@ func (e *Engine) CreateContext(w http.ResponseWriter, r *http.Request, params Pa
 	c.params = params
 	c.handler = NotFoundHandler
 	c.query = nil
+	c.stdctx = r.Context()
 	return c
 }
 
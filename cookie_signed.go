@@ -0,0 +1,200 @@
+package fin
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CookieOptions configures a cookie written by SignedCookie. HttpOnly
+// and Secure are pointers so an unset field can fall back to the
+// package defaults (HttpOnly=true, Secure=IsTLS()) instead of Go's
+// zero value for bool.
+type CookieOptions struct {
+	Path     string
+	Domain   string
+	Expires  time.Time
+	MaxAge   int
+	HttpOnly *bool
+	Secure   *bool
+	SameSite http.SameSite
+
+	// Encrypt additionally seals the value with AES-GCM, using a key
+	// derived from Engine's first SigningKey, before signing it.
+	Encrypt bool
+}
+
+func (c *ctx) withDefaults(opts CookieOptions) CookieOptions {
+	if opts.HttpOnly == nil {
+		httpOnly := true
+		opts.HttpOnly = &httpOnly
+	}
+	if opts.Secure == nil {
+		secure := c.IsTLS()
+		opts.Secure = &secure
+	}
+	if opts.SameSite == http.SameSiteDefaultMode {
+		opts.SameSite = http.SameSiteLaxMode
+	}
+	return opts
+}
+
+// SignedCookie sets a cookie whose value is HMAC-signed with the first
+// of Engine's SigningKeys, so GetSignedCookie can detect tampering.
+// Key rotation is supported: only the first key is used to sign, but
+// GetSignedCookie accepts a value signed by any configured key.
+func (c *ctx) SignedCookie(name, value string, opts CookieOptions) error {
+	if len(c.signingKeys) == 0 {
+		return ErrNoSigningKeys
+	}
+	opts = c.withDefaults(opts)
+	if opts.Encrypt {
+		encrypted, err := encrypt(c.signingKeys[0], value)
+		if err != nil {
+			return err
+		}
+		value = encrypted
+	}
+	signed := sign(c.signingKeys[0], name, value)
+	c.SetCookie(&http.Cookie{
+		Name:     name,
+		Value:    signed,
+		Path:     opts.Path,
+		Domain:   opts.Domain,
+		Expires:  opts.Expires,
+		MaxAge:   opts.MaxAge,
+		HttpOnly: *opts.HttpOnly,
+		Secure:   *opts.Secure,
+		SameSite: opts.SameSite,
+	})
+	return nil
+}
+
+// GetSignedCookie reads back a cookie set by SignedCookie, verifying
+// its signature against every key in Engine's SigningKeys so rotation
+// doesn't invalidate cookies signed with an older key.
+func (c *ctx) GetSignedCookie(name string) (string, error) {
+	if len(c.signingKeys) == 0 {
+		return "", ErrNoSigningKeys
+	}
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+	return verifySignedValue(c.signingKeys, name, cookie.Value)
+}
+
+// verifySignedValue splits a cookie value produced by sign (the
+// base64-encoded value, then the last ".", then the base64-encoded
+// MAC) and checks it against every candidate key; once a key's MAC
+// matches, it decodes the value and decrypts it with that key if it
+// carries encryptedPrefix. The split uses the last "." rather
+// than the first so a base64-encoded value containing a literal "."
+// (RawURLEncoding never emits one, but a future encoding might) still
+// round-trips correctly.
+func verifySignedValue(keys [][]byte, name, raw string) (string, error) {
+	i := strings.LastIndex(raw, ".")
+	if i < 0 {
+		return "", ErrInvalidCookieSignature
+	}
+	encodedValue, mac := raw[:i], raw[i+1:]
+	want, err := base64.RawURLEncoding.DecodeString(mac)
+	if err != nil {
+		return "", ErrInvalidCookieSignature
+	}
+	for _, key := range keys {
+		if !hmac.Equal(want, hmacSum(key, name, encodedValue)) {
+			continue
+		}
+		decoded, err := base64.RawURLEncoding.DecodeString(encodedValue)
+		if err != nil {
+			return "", ErrInvalidCookieSignature
+		}
+		value := string(decoded)
+		if strings.HasPrefix(value, encryptedPrefix) {
+			return decrypt(key, strings.TrimPrefix(value, encryptedPrefix))
+		}
+		return value, nil
+	}
+	return "", ErrInvalidCookieSignature
+}
+
+const encryptedPrefix = "enc:"
+
+// encrypt seals value with AES-256-GCM using a key derived from the
+// signing key, prefixing the result so GetSignedCookie can tell an
+// encrypted payload apart from a plain signed one.
+func encrypt(key []byte, value string) (string, error) {
+	block, err := aes.NewCipher(deriveAESKey(key))
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func decrypt(key []byte, encoded string) (string, error) {
+	block, err := aes.NewCipher(deriveAESKey(key))
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidCookieSignature
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", ErrInvalidCookieSignature
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrInvalidCookieSignature
+	}
+	return string(plain), nil
+}
+
+// deriveAESKey stretches an arbitrary-length signing key into the 32
+// bytes AES-256 requires.
+func deriveAESKey(key []byte) []byte {
+	sum := sha256.Sum256(key)
+	return sum[:]
+}
+
+// sign base64-encodes value before computing and appending its MAC, so
+// the cookie value written to the wire contains only the unreserved
+// base64url alphabet. Without this, http.SetCookie's sanitizeCookieValue
+// silently strips bytes like non-ASCII characters, quotes, semicolons,
+// and backslashes, so the bytes the MAC was computed over would differ
+// from what's actually transmitted.
+func sign(key []byte, name, value string) string {
+	encodedValue := base64.RawURLEncoding.EncodeToString([]byte(value))
+	mac := hmacSum(key, name, encodedValue)
+	return encodedValue + "." + base64.RawURLEncoding.EncodeToString(mac)
+}
+
+func hmacSum(key []byte, name, value string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(name))
+	h.Write([]byte("="))
+	h.Write([]byte(value))
+	return h.Sum(nil)
+}
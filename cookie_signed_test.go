@@ -0,0 +1,113 @@
+package fin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSignAndVerifySignedValue(t *testing.T) {
+	keys := [][]byte{[]byte("current-secret"), []byte("old-secret")}
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"session", "user=a.b@example.com"},
+		{"expiry", "2026-07-27T12:00:00.000Z"},
+		{"amount", "19.99"},
+		{"plain", "abc123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed := sign(keys[0], tt.name, tt.value)
+			got, err := verifySignedValue(keys, tt.name, signed)
+			if err != nil {
+				t.Fatalf("verifySignedValue() error = %v", err)
+			}
+			if got != tt.value {
+				t.Fatalf("verifySignedValue() = %q, want %q", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestVerifySignedValueKeyRotation(t *testing.T) {
+	oldKey := []byte("old-secret")
+	keys := [][]byte{[]byte("current-secret"), oldKey}
+
+	signed := sign(oldKey, "session", "still-valid")
+	got, err := verifySignedValue(keys, "session", signed)
+	if err != nil {
+		t.Fatalf("verifySignedValue() error = %v", err)
+	}
+	if got != "still-valid" {
+		t.Fatalf("verifySignedValue() = %q, want %q", got, "still-valid")
+	}
+}
+
+func TestVerifySignedValueRejectsTamperedValue(t *testing.T) {
+	keys := [][]byte{[]byte("secret")}
+	alice := sign(keys[0], "session", "alice")
+	mallory := sign(keys[0], "session", "mallory")
+
+	// Splice mallory's encoded value onto alice's MAC: the MAC no
+	// longer matches the (now different) encoded value, so this must
+	// be rejected.
+	tampered := mallory[:strings.LastIndex(mallory, ".")] + alice[strings.LastIndex(alice, "."):]
+
+	if _, err := verifySignedValue(keys, "session", tampered); err != ErrInvalidCookieSignature {
+		t.Fatalf("verifySignedValue() error = %v, want %v", err, ErrInvalidCookieSignature)
+	}
+}
+
+func TestVerifySignedValueMissingSeparator(t *testing.T) {
+	if _, err := verifySignedValue([][]byte{[]byte("secret")}, "session", "no-separator-here"); err != ErrInvalidCookieSignature {
+		t.Fatalf("verifySignedValue() error = %v, want %v", err, ErrInvalidCookieSignature)
+	}
+}
+
+// TestSignedCookieValueSurvivesHTTPCookieRoundTrip guards against
+// http.SetCookie's sanitizeCookieValue silently dropping bytes outside
+// its allowed set (non-ASCII, quotes, semicolons, backslashes) before
+// the MAC is checked, which would make GetSignedCookie reject any such
+// value even though SignedCookie wrote it successfully.
+func TestSignedCookieValueSurvivesHTTPCookieRoundTrip(t *testing.T) {
+	key := []byte("secret")
+	value := `café "; \ value`
+	signed := sign(key, "session", value)
+
+	rec := httptest.NewRecorder()
+	http.SetCookie(rec, &http.Cookie{Name: "session", Value: signed})
+
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("got %d cookies, want 1", len(cookies))
+	}
+
+	got, err := verifySignedValue([][]byte{key}, "session", cookies[0].Value)
+	if err != nil {
+		t.Fatalf("verifySignedValue() error = %v", err)
+	}
+	if got != value {
+		t.Fatalf("verifySignedValue() = %q, want %q", got, value)
+	}
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	key := []byte("secret")
+	encrypted, err := encrypt(key, "top-secret-value")
+	if err != nil {
+		t.Fatalf("encrypt() error = %v", err)
+	}
+	signed := sign(key, "session", encrypted)
+
+	got, err := verifySignedValue([][]byte{key}, "session", signed)
+	if err != nil {
+		t.Fatalf("verifySignedValue() error = %v", err)
+	}
+	if got != "top-secret-value" {
+		t.Fatalf("verifySignedValue() = %q, want %q", got, "top-secret-value")
+	}
+}
@@ -0,0 +1,90 @@
+package fin
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/Code-Hex/fin/internal/mime"
+)
+
+// jsonpCallbackRE matches a safe JavaScript identifier/member-access
+// expression, e.g. "cb", "angular.callbacks._0", "foo[0].bar". Anything
+// else is rejected so an attacker can't break out of the
+// application/javascript response with arbitrary script content.
+var jsonpCallbackRE = regexp.MustCompile(`^[a-zA-Z_$][\w$]*(\[\d+\])*(\.[a-zA-Z_$][\w$]*(\[\d+\])*)*$`)
+
+// ProtobufEncoder marshals a protobuf message into bytes. m is typed as
+// interface{}, not proto.Message, so fin's core package doesn't import
+// a protobuf runtime; register an implementation on Engine that
+// type-asserts m to whatever concrete message type it expects.
+type ProtobufEncoder interface {
+	Marshal(m interface{}) ([]byte, error)
+}
+
+// MsgPackEncoder marshals a value into MessagePack bytes. Register an
+// implementation on Engine to enable Context.MsgPack.
+type MsgPackEncoder interface {
+	Marshal(i interface{}) ([]byte, error)
+}
+
+func (c *ctx) JSONBlob(code int, b []byte) error {
+	return c.Blob(code, mime.ApplicationJSONCharsetUTF8, b)
+}
+
+// JSONP writes i as JSON wrapped in the given JavaScript callback.
+// callback must be a bare identifier or member-access expression;
+// anything else is rejected to prevent callback-injection/XSS.
+func (c *ctx) JSONP(code int, callback string, i interface{}) error {
+	if !jsonpCallbackRE.MatchString(callback) {
+		return ErrInvalidJSONPCallback
+	}
+	b, err := c.marshalJSON(i)
+	if err != nil {
+		return err
+	}
+	c.SetContentType(code, mime.ApplicationJavaScriptCharsetUTF8)
+	if _, err := fmt.Fprintf(c.response, "%s(", callback); err != nil {
+		return err
+	}
+	if _, err := c.response.Write(b); err != nil {
+		return err
+	}
+	_, err = c.response.Write([]byte(");"))
+	return err
+}
+
+func (c *ctx) marshalJSON(i interface{}) ([]byte, error) {
+	if _, pretty := c.QueryParams()["pretty"]; pretty {
+		return json.MarshalIndent(i, "", indent)
+	}
+	return json.Marshal(i)
+}
+
+func (c *ctx) XMLBlob(code int, b []byte) error {
+	return c.Blob(code, mime.ApplicationXMLCharsetUTF8, b)
+}
+
+// Protobuf encodes m using the ProtobufEncoder registered on Engine.
+func (c *ctx) Protobuf(code int, m interface{}) error {
+	if c.protobufEncoder == nil {
+		return ErrEncoderNotRegistered
+	}
+	b, err := c.protobufEncoder.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return c.Blob(code, mime.ApplicationProtobuf, b)
+}
+
+// MsgPack encodes i using the MsgPackEncoder registered on Engine.
+func (c *ctx) MsgPack(code int, i interface{}) error {
+	if c.msgPackEncoder == nil {
+		return ErrEncoderNotRegistered
+	}
+	b, err := c.msgPackEncoder.Marshal(i)
+	if err != nil {
+		return err
+	}
+	return c.Blob(code, mime.ApplicationMsgPack, b)
+}
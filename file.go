@@ -0,0 +1,121 @@
+package fin
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/Code-Hex/fin/internal/header"
+)
+
+func (c *ctx) Blob(code int, contentType string, b []byte) error {
+	c.response.Header().Set(header.ContentType, contentType)
+	c.response.WriteHeader(code)
+	_, err := c.response.Write(b)
+	return err
+}
+
+func (c *ctx) Stream(code int, contentType string, r io.Reader) error {
+	c.response.Header().Set(header.ContentType, contentType)
+	c.response.WriteHeader(code)
+	_, err := io.Copy(c.response, r)
+	return err
+}
+
+// File writes the contents of the named file to the response, relying on
+// http.ServeContent for range requests, conditional GETs, and MIME
+// sniffing when the extension is unknown.
+func (c *ctx) File(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return ErrNotFound
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		index := filepath.Join(file, "index.html")
+		f.Close()
+		f, err = os.Open(index)
+		if err != nil {
+			return ErrNotFound
+		}
+		defer f.Close()
+		if fi, err = f.Stat(); err != nil {
+			return err
+		}
+	}
+
+	http.ServeContent(c.response, c.request, fi.Name(), fi.ModTime(), f)
+	return nil
+}
+
+// Attachment sends the named file as a download, prompting the browser
+// to save it as name.
+func (c *ctx) Attachment(file, name string) error {
+	return c.contentDisposition(file, name, "attachment")
+}
+
+// Inline sends the named file for in-browser rendering under the given
+// display name.
+func (c *ctx) Inline(file, name string) error {
+	return c.contentDisposition(file, name, "inline")
+}
+
+func (c *ctx) contentDisposition(file, name, disposition string) error {
+	c.response.Header().Set(header.ContentDisposition, fmt.Sprintf(
+		`%s; filename="%s"; filename*=UTF-8''%s`,
+		disposition, quoteFilename(name), rfc5987Encode(name),
+	))
+	return c.File(file)
+}
+
+func quoteFilename(name string) string {
+	r := make([]byte, 0, len(name))
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; c == '"' || c == '\\' {
+			r = append(r, '\\')
+		}
+		r = append(r, name[i])
+	}
+	return string(r)
+}
+
+// rfc5987AttrChar reports whether b is in RFC 5987's attr-char set
+// (ALPHA / DIGIT / "!" / "#" / "$" / "&" / "+" / "-" / "." / "^" / "_"
+// / "`" / "|" / "~"), the characters an ext-value may carry unescaped.
+func rfc5987AttrChar(b byte) bool {
+	switch {
+	case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9':
+		return true
+	}
+	switch b {
+	case '!', '#', '$', '&', '+', '-', '.', '^', '_', '`', '|', '~':
+		return true
+	}
+	return false
+}
+
+// rfc5987Encode percent-encodes name for use as the value of an
+// RFC 5987 ext-value, i.e. the part after filename*=UTF-8 followed by
+// a pair of single quotes, escaping every byte outside attr-char
+// instead of reusing url.PathEscape, which leaves characters like
+// ':', '=', and '@' unescaped.
+func rfc5987Encode(name string) string {
+	const hex = "0123456789ABCDEF"
+	b := []byte(name)
+	out := make([]byte, 0, len(b)*3)
+	for _, c := range b {
+		if rfc5987AttrChar(c) {
+			out = append(out, c)
+			continue
+		}
+		out = append(out, '%', hex[c>>4], hex[c&0x0f])
+	}
+	return string(out)
+}
@@ -0,0 +1,85 @@
+package fin
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestQuoteFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{`say "hi".txt`, `say \"hi\".txt`},
+		{`back\slash.txt`, `back\\slash.txt`},
+	}
+	for _, tt := range tests {
+		if got := quoteFilename(tt.name); got != tt.want {
+			t.Errorf("quoteFilename(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRfc5987Encode(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"a b.txt", "a%20b.txt"},
+		{"café.txt", "caf%C3%A9.txt"},
+		{"100% done.txt", "100%25%20done.txt"},
+	}
+	for _, tt := range tests {
+		if got := rfc5987Encode(tt.name); got != tt.want {
+			t.Errorf("rfc5987Encode(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestContentDispositionHeader(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "fin-test-*.txt")
+	if err != nil {
+		t.Fatalf("CreateTemp() error = %v", err)
+	}
+	if _, err := f.WriteString("hello"); err != nil {
+		t.Fatalf("WriteString() error = %v", err)
+	}
+	f.Close()
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rec := httptest.NewRecorder()
+	c := &ctx{request: req, response: NewResponse(rec)}
+
+	if err := c.Attachment(f.Name(), `report "final".pdf`); err != nil {
+		t.Fatalf("Attachment() error = %v", err)
+	}
+
+	want := `attachment; filename="report \"final\".pdf"; filename*=UTF-8''report%20%22final%22.pdf`
+	if got := rec.Header().Get("Content-Disposition"); got != want {
+		t.Errorf("Content-Disposition = %q, want %q", got, want)
+	}
+}
+
+func TestContentDispositionHeaderInline(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "fin-test-*.txt")
+	if err != nil {
+		t.Fatalf("CreateTemp() error = %v", err)
+	}
+	f.Close()
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rec := httptest.NewRecorder()
+	c := &ctx{request: req, response: NewResponse(rec)}
+
+	if err := c.Inline(f.Name(), "café.txt"); err != nil {
+		t.Fatalf("Inline() error = %v", err)
+	}
+
+	want := `inline; filename="café.txt"; filename*=UTF-8''caf%C3%A9.txt`
+	if got := rec.Header().Get("Content-Disposition"); got != want {
+		t.Errorf("Content-Disposition = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package fin
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Code-Hex/fin/internal/header"
+	"github.com/Code-Hex/fin/internal/mime"
+)
+
+// acceptSpec is one entry of an Accept-style header: a type/subtype (or
+// charset/encoding/language) token together with its q-value.
+type acceptSpec struct {
+	value string
+	q     float64
+}
+
+// parseAccept splits an Accept-style header value into specs ordered
+// from most to least preferred, honoring explicit q-values and falling
+// back to declaration order for ties.
+func parseAccept(header string) []acceptSpec {
+	parts := strings.Split(header, ",")
+	specs := make([]acceptSpec, 0, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		value, params, _ := strings.Cut(part, ";")
+		spec := acceptSpec{value: strings.TrimSpace(value), q: 1.0}
+		for _, p := range strings.Split(params, ";") {
+			k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+			if ok && strings.EqualFold(strings.TrimSpace(k), "q") {
+				if q, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+					spec.q = q
+				}
+			}
+		}
+		// Stash declaration order in a tiny negative offset so a stable
+		// sort by q alone still prefers earlier entries on ties.
+		spec.q -= float64(i) * 1e-6
+		specs = append(specs, spec)
+	}
+	sort.SliceStable(specs, func(i, j int) bool { return specs[i].q > specs[j].q })
+	return specs
+}
+
+// bestOffer returns whichever offer best matches the parsed specs,
+// honoring exact matches, "type/*" wildcards, and "*/*" wildcards.
+func bestOffer(specs []acceptSpec, offers []string) string {
+	if len(specs) == 0 {
+		if len(offers) > 0 {
+			return offers[0]
+		}
+		return ""
+	}
+	for _, spec := range specs {
+		if spec.q <= 0 {
+			continue
+		}
+		for _, offer := range offers {
+			if matchesOffer(spec.value, offer) {
+				return offer
+			}
+		}
+	}
+	return ""
+}
+
+func matchesOffer(accept, offer string) bool {
+	if accept == "*" || accept == "*/*" {
+		return true
+	}
+	if strings.EqualFold(accept, offer) {
+		return true
+	}
+	acceptType, acceptSub, ok := strings.Cut(accept, "/")
+	if !ok {
+		return false
+	}
+	offerType, offerSub, ok := strings.Cut(offer, "/")
+	if !ok {
+		return false
+	}
+	if acceptType != "*" && !strings.EqualFold(acceptType, offerType) {
+		return false
+	}
+	if acceptSub != "*" && !strings.EqualFold(acceptSub, offerSub) {
+		return false
+	}
+	return true
+}
+
+// Accepts returns the offer that best matches the request's Accept
+// header, honoring q-values and type/* or */* wildcards. It returns ""
+// if none of the offers are acceptable.
+func (c *ctx) Accepts(offers ...string) string {
+	return bestOffer(parseAccept(c.request.Header.Get(header.Accept)), offers)
+}
+
+// AcceptsCharsets negotiates against Accept-Charset.
+func (c *ctx) AcceptsCharsets(offers ...string) string {
+	return bestOffer(parseAccept(c.request.Header.Get(header.AcceptCharset)), offers)
+}
+
+// AcceptsEncodings negotiates against Accept-Encoding.
+func (c *ctx) AcceptsEncodings(offers ...string) string {
+	return bestOffer(parseAccept(c.request.Header.Get(header.AcceptEncoding)), offers)
+}
+
+// AcceptsLanguages negotiates against Accept-Language.
+func (c *ctx) AcceptsLanguages(offers ...string) string {
+	return bestOffer(parseAccept(c.request.Header.Get(header.AcceptLanguage)), offers)
+}
+
+// Respond picks JSON, XML, or HTML for data based on content negotiation
+// against the request's Accept header. HTML is written only for string
+// data; other data is sent as JSON when text/html wins. When nothing
+// matches it falls back to Engine's DefaultRenderType: application/xml
+// selects XML, and any other value selects JSON.
+func (c *ctx) Respond(code int, data interface{}) error {
+	switch c.Accepts(mime.ApplicationJSON, mime.ApplicationXML, mime.TextHTML) {
+	case mime.ApplicationXML:
+		return c.XML(code, data)
+	case mime.TextHTML:
+		if s, ok := data.(string); ok {
+			return c.Blob(code, mime.TextHTML, []byte(s))
+		}
+		return c.JSON(code, data)
+	case mime.ApplicationJSON:
+		return c.JSON(code, data)
+	default:
+		switch c.defaultRenderType {
+		case mime.ApplicationXML:
+			return c.XML(code, data)
+		default:
+			return c.JSON(code, data)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package fin
+
+import "testing"
+
+func TestBestOfferHonorsQValues(t *testing.T) {
+	specs := parseAccept("text/html;q=0.8, application/json;q=0.9, application/xml;q=0.1")
+	got := bestOffer(specs, []string{"text/html", "application/json", "application/xml"})
+	if got != "application/json" {
+		t.Fatalf("bestOffer() = %q, want %q", got, "application/json")
+	}
+}
+
+func TestBestOfferWildcards(t *testing.T) {
+	tests := []struct {
+		accept string
+		offers []string
+		want   string
+	}{
+		{"*/*", []string{"application/json"}, "application/json"},
+		{"application/*", []string{"text/html", "application/xml"}, "application/xml"},
+		{"text/plain", []string{"application/json", "text/plain"}, "text/plain"},
+	}
+	for _, tt := range tests {
+		got := bestOffer(parseAccept(tt.accept), tt.offers)
+		if got != tt.want {
+			t.Errorf("bestOffer(%q, %v) = %q, want %q", tt.accept, tt.offers, got, tt.want)
+		}
+	}
+}
+
+func TestBestOfferNoMatch(t *testing.T) {
+	got := bestOffer(parseAccept("application/json"), []string{"text/html"})
+	if got != "" {
+		t.Fatalf("bestOffer() = %q, want empty string", got)
+	}
+}
+
+func TestBestOfferEmptyAcceptHeaderFallsBackToFirstOffer(t *testing.T) {
+	got := bestOffer(parseAccept(""), []string{"text/html", "application/json"})
+	if got != "text/html" {
+		t.Fatalf("bestOffer() = %q, want %q", got, "text/html")
+	}
+}
+
+func TestParseAcceptPreservesDeclarationOrderOnTies(t *testing.T) {
+	specs := parseAccept("application/xml, application/json")
+	if len(specs) != 2 || specs[0].value != "application/xml" || specs[1].value != "application/json" {
+		t.Fatalf("parseAccept() = %+v, want application/xml before application/json", specs)
+	}
+}
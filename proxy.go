@@ -0,0 +1,113 @@
+package fin
+
+import (
+	"net"
+	"strings"
+
+	"github.com/Code-Hex/fin/internal/header"
+)
+
+func (c *ctx) IsTLS() bool {
+	return c.request.TLS != nil
+}
+
+func (c *ctx) IsWebSocket() bool {
+	if !strings.EqualFold(c.request.Header.Get(header.Connection), "upgrade") {
+		return false
+	}
+	return strings.EqualFold(c.request.Header.Get(header.Upgrade), "websocket")
+}
+
+// Scheme returns "https" if the connection is TLS, or if a trusted
+// proxy reports TLS termination via X-Forwarded-Proto, X-Forwarded-Ssl,
+// or Forwarded. Otherwise it returns "http".
+func (c *ctx) Scheme() string {
+	if c.IsTLS() {
+		return "https"
+	}
+	if !c.isTrustedPeer() {
+		return "http"
+	}
+	if proto := c.request.Header.Get(header.XForwardedProto); proto != "" {
+		return strings.ToLower(strings.TrimSpace(strings.SplitN(proto, ",", 2)[0]))
+	}
+	if strings.EqualFold(c.request.Header.Get(header.XForwardedSsl), "on") {
+		return "https"
+	}
+	if fwd := c.request.Header.Get(header.Forwarded); fwd != "" {
+		// RFC 7239: the header is a comma-separated list of forwarded
+		// elements, each a semicolon-separated list of key=value
+		// pairs. Only the first element (the client-nearest hop)
+		// matters here, so split on "," before splitting on ";".
+		first, _, _ := strings.Cut(fwd, ",")
+		for _, part := range strings.Split(first, ";") {
+			k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+			if ok && strings.EqualFold(k, "proto") {
+				return strings.ToLower(strings.Trim(v, `"`))
+			}
+		}
+	}
+	return "http"
+}
+
+// RealIP returns the client's IP address, honoring X-Forwarded-For and
+// X-Real-IP only when the direct peer is a trusted proxy, to prevent
+// spoofing when fin is deployed behind an untrusted load balancer.
+func (c *ctx) RealIP() string {
+	if c.isTrustedPeer() {
+		if fwdFor := c.request.Header.Get(header.XForwardedFor); fwdFor != "" {
+			for _, ip := range strings.Split(fwdFor, ",") {
+				ip = strings.TrimSpace(ip)
+				if ip != "" && isPublicIP(ip) {
+					return ip
+				}
+			}
+		}
+		if realIP := c.request.Header.Get(header.XRealIP); realIP != "" {
+			return realIP
+		}
+	}
+	ip, _, err := net.SplitHostPort(c.request.RemoteAddr)
+	if err != nil {
+		return c.request.RemoteAddr
+	}
+	return ip
+}
+
+// isPublicIP reports whether ip parses as an address that isn't
+// private, loopback, link-local, or unspecified, so X-Forwarded-For
+// chains skip internal hops and land on the first public address.
+func isPublicIP(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	return !parsed.IsPrivate() &&
+		!parsed.IsLoopback() &&
+		!parsed.IsLinkLocalUnicast() &&
+		!parsed.IsLinkLocalMulticast() &&
+		!parsed.IsUnspecified()
+}
+
+// isTrustedPeer reports whether the direct TCP peer is within one of the
+// Engine's configured TrustedProxies. With no TrustedProxies configured,
+// forwarding headers are never honored.
+func (c *ctx) isTrustedPeer() bool {
+	if len(c.trustedProxies) == 0 {
+		return false
+	}
+	host, _, err := net.SplitHostPort(c.request.RemoteAddr)
+	if err != nil {
+		host = c.request.RemoteAddr
+	}
+	peer := net.ParseIP(host)
+	if peer == nil {
+		return false
+	}
+	for _, n := range c.trustedProxies {
+		if n.Contains(peer) {
+			return true
+		}
+	}
+	return false
+}
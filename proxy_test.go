@@ -0,0 +1,132 @@
+package fin
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newProxyTestCtx(remoteAddr string, trusted []string, header http.Header) *ctx {
+	proxies := make([]*net.IPNet, 0, len(trusted))
+	for _, cidr := range trusted {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		proxies = append(proxies, n)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	if header != nil {
+		req.Header = header
+	}
+	return &ctx{request: req, trustedProxies: proxies}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"2.3.4.5", true},
+		{"203.0.113.7", true},
+		{"10.0.0.5", false},
+		{"172.16.5.1", false},
+		{"192.168.1.1", false},
+		{"127.0.0.1", false},
+		{"169.254.1.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fe80::1", false},
+		{"not-an-ip", false},
+	}
+	for _, tt := range tests {
+		if got := isPublicIP(tt.ip); got != tt.want {
+			t.Errorf("isPublicIP(%q) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestIsTrustedPeer(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		trusted    []string
+		want       bool
+	}{
+		{"no trusted proxies configured", "10.0.0.1:1234", nil, false},
+		{"peer inside trusted CIDR", "10.0.0.1:1234", []string{"10.0.0.0/8"}, true},
+		{"peer outside trusted CIDR", "203.0.113.9:1234", []string{"10.0.0.0/8"}, false},
+		{"addr without port", "10.0.0.1", []string{"10.0.0.0/8"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newProxyTestCtx(tt.remoteAddr, tt.trusted, nil)
+			if got := c.isTrustedPeer(); got != tt.want {
+				t.Errorf("isTrustedPeer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRealIPIgnoresHeadersFromUntrustedPeer(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Forwarded-For", "198.51.100.7")
+	header.Set("X-Real-Ip", "198.51.100.8")
+
+	c := newProxyTestCtx("203.0.113.9:1234", nil, header)
+	if got, want := c.RealIP(), "203.0.113.9"; got != want {
+		t.Errorf("RealIP() = %q, want %q (spoofed headers from untrusted peer must be ignored)", got, want)
+	}
+}
+
+func TestRealIPHonorsHeadersFromTrustedPeer(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Forwarded-For", "198.51.100.7, 10.0.0.1")
+
+	c := newProxyTestCtx("10.0.0.1:1234", []string{"10.0.0.0/8"}, header)
+	if got, want := c.RealIP(), "198.51.100.7"; got != want {
+		t.Errorf("RealIP() = %q, want %q", got, want)
+	}
+}
+
+func TestRealIPFallsBackToXRealIP(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Real-Ip", "198.51.100.8")
+
+	c := newProxyTestCtx("10.0.0.1:1234", []string{"10.0.0.0/8"}, header)
+	if got, want := c.RealIP(), "198.51.100.8"; got != want {
+		t.Errorf("RealIP() = %q, want %q", got, want)
+	}
+}
+
+func TestSchemeIgnoresHeadersFromUntrustedPeer(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Forwarded-Proto", "https")
+
+	c := newProxyTestCtx("203.0.113.9:1234", nil, header)
+	if got, want := c.Scheme(), "http"; got != want {
+		t.Errorf("Scheme() = %q, want %q (spoofed header from untrusted peer must be ignored)", got, want)
+	}
+}
+
+func TestSchemeHonorsXForwardedProtoFromTrustedPeer(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Forwarded-Proto", "https, http")
+
+	c := newProxyTestCtx("10.0.0.1:1234", []string{"10.0.0.0/8"}, header)
+	if got, want := c.Scheme(), "https"; got != want {
+		t.Errorf("Scheme() = %q, want %q", got, want)
+	}
+}
+
+func TestSchemeParsesForwardedHeaderFirstElement(t *testing.T) {
+	header := http.Header{}
+	header.Set("Forwarded", `for=192.0.2.1;proto=http, for=198.51.100.2;proto=https`)
+
+	c := newProxyTestCtx("10.0.0.1:1234", []string{"10.0.0.0/8"}, header)
+	if got, want := c.Scheme(), "http"; got != want {
+		t.Errorf("Scheme() = %q, want %q (must use proto from the first forwarded-element only)", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+package fin
+
+import (
+	stdctx "context"
+	"time"
+)
+
+// CancelFunc cancels the stdctx.Context that Context.WithTimeout
+// installed on the Context it returns. Calling it releases resources associated with
+// the deadline and should always happen, typically via defer.
+type CancelFunc stdctx.CancelFunc
+
+// Deadline, Done, Err, and Value make *ctx satisfy context.Context,
+// delegating to the stdctx.Context tracked on c (see StdContext).
+
+func (c *ctx) Deadline() (deadline time.Time, ok bool) {
+	return c.StdContext().Deadline()
+}
+
+func (c *ctx) Done() <-chan struct{} {
+	return c.StdContext().Done()
+}
+
+func (c *ctx) Err() error {
+	return c.StdContext().Err()
+}
+
+func (c *ctx) Value(key interface{}) interface{} {
+	return c.StdContext().Value(key)
+}
+
+// StdContext returns the stdctx.Context backing this Context, starting
+// from request.Context() and replaceable via SetStdContext.
+func (c *ctx) StdContext() stdctx.Context {
+	if c.stdctx == nil {
+		return c.request.Context()
+	}
+	return c.stdctx
+}
+
+// SetStdContext replaces the stdctx.Context backing this Context and
+// rebinds c.request to it via r.WithContext, so the change propagates
+// to downstream calls that read from the request.
+func (c *ctx) SetStdContext(ctx stdctx.Context) {
+	c.stdctx = ctx
+	c.request = c.request.WithContext(ctx)
+}
+
+// WithTimeout replaces c's stdctx.Context with a child that is canceled
+// after d and returns c itself, not a copy. It rebinds the underlying
+// *http.Request so the deadline propagates to downstream calls (DB,
+// outbound HTTP clients, etc).
+func (c *ctx) WithTimeout(d time.Duration) (Context, CancelFunc) {
+	ctx, cancel := stdctx.WithTimeout(c.StdContext(), d)
+	c.SetStdContext(ctx)
+	return c, CancelFunc(cancel)
+}
@@ -0,0 +1,76 @@
+package fin
+
+import (
+	stdctx "context"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSetStdContext(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	c := &ctx{request: req}
+
+	type key struct{}
+	parent := stdctx.WithValue(stdctx.Background(), key{}, "value")
+	c.SetStdContext(parent)
+
+	if got := c.StdContext(); got != parent {
+		t.Fatalf("StdContext() = %v, want %v", got, parent)
+	}
+	if got := c.request.Context(); got != parent {
+		t.Fatalf("request.Context() = %v, want %v (SetStdContext must rebind the request)", got, parent)
+	}
+	if got := c.Value(key{}); got != "value" {
+		t.Fatalf("Value() = %v, want %q", got, "value")
+	}
+}
+
+func TestWithTimeoutPropagatesDeadline(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	c := &ctx{request: req}
+
+	derived, cancel := c.WithTimeout(10 * time.Millisecond)
+	defer cancel()
+
+	if derived != Context(c) {
+		t.Fatalf("WithTimeout() returned a different Context than c")
+	}
+
+	deadline, ok := c.Deadline()
+	if !ok {
+		t.Fatal("Deadline() ok = false, want true")
+	}
+	if time.Until(deadline) > 10*time.Millisecond {
+		t.Fatalf("Deadline() = %v, want within 10ms from now", deadline)
+	}
+	if got := c.request.Context().Err(); got != nil {
+		t.Fatalf("request.Context().Err() = %v, want nil before timeout", got)
+	}
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done() channel never closed after timeout elapsed")
+	}
+	if got := c.Err(); got != stdctx.DeadlineExceeded {
+		t.Fatalf("Err() = %v, want %v", got, stdctx.DeadlineExceeded)
+	}
+}
+
+func TestWithTimeoutCancelFunc(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	c := &ctx{request: req}
+
+	_, cancel := c.WithTimeout(time.Hour)
+	cancel()
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done() channel never closed after calling cancel")
+	}
+	if got := c.Err(); got != stdctx.Canceled {
+		t.Fatalf("Err() = %v, want %v", got, stdctx.Canceled)
+	}
+}